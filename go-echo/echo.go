@@ -3,21 +3,179 @@ package main
 import (
 	"context"
 	"errors"
+	"github.com/getsentry/sentry-go"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"log/slog"
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel/attribute"
+	traceotel "go.opentelemetry.io/otel/trace"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// parseHeaderNames parses a comma-separated allow-list of header names (as
+// used by HTTP_LOG_REQUEST_HEADERS, HTTP_LOG_RESPONSE_HEADERS, and
+// HTTP_LOG_REDACT_HEADERS) into their canonical form.
+func parseHeaderNames(val string) []string {
+	var out []string
+	for _, h := range strings.Split(val, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			out = append(out, http.CanonicalHeaderKey(h))
+		}
+	}
+	return out
+}
+
+// headerAttrs builds the slog and OTel span attributes for the configured
+// header allow-list on a single request or response, following the
+// http.<kind>.header.<name> semantic convention. Headers in redact are
+// recorded with a "[REDACTED]" sentinel instead of their real value.
+func headerAttrs(kind string, header http.Header, names []string, redact map[string]struct{}) ([]slog.Attr, []attribute.KeyValue) {
+	var slogAttrs []slog.Attr
+	var spanAttrs []attribute.KeyValue
+	for _, name := range names {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		val := strings.Join(values, ",")
+		if _, ok := redact[name]; ok {
+			val = "[REDACTED]"
+		}
+		key := fmt.Sprintf("http.%s.header.%s", kind, strings.ToLower(name))
+		slogAttrs = append(slogAttrs, slog.String(key, val))
+		spanAttrs = append(spanAttrs, attribute.String(key, val))
+	}
+	return slogAttrs, spanAttrs
+}
+
+// setupSentry initializes the Sentry client from SENTRY_DSN. It reports
+// false if Sentry is not configured, so callers can skip wiring the
+// Sentry-aware middleware and error forwarding entirely.
+func setupSentry() (bool, error) {
+	dsn := strings.TrimSpace(os.Getenv("SENTRY_DSN"))
+	if dsn == "" {
+		return false, nil
+	}
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:              dsn,
+		AttachStacktrace: true,
+	}); err != nil {
+		return false, err
+	}
+	slog.Info("web_request.sentry_enabled")
+	return true, nil
+}
+
+func boolEnv(name string, def bool) bool {
+	val := strings.TrimSpace(os.Getenv(name))
+	if val == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+func durationEnv(name string, def time.Duration) time.Duration {
+	val := strings.TrimSpace(os.Getenv(name))
+	if val == "" {
+		return def
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// sentryHubContextKey is the echo.Context key sentryMiddleware stores the
+// per-request Sentry hub under.
+const sentryHubContextKey = "sentry_hub"
+
+// hubFromContext retrieves the per-request Sentry hub stashed by
+// sentryMiddleware, falling back to the global hub if called outside of it.
+func hubFromContext(c echo.Context) *sentry.Hub {
+	if hub, ok := c.Get(sentryHubContextKey).(*sentry.Hub); ok {
+		return hub
+	}
+	return sentry.CurrentHub()
+}
+
+// sentryMiddleware clones the Sentry hub per request, the same way
+// github.com/getsentry/sentry-go/echo does, and attaches it to both the
+// echo.Context and the request context so handlers and captureToSentry can
+// retrieve it. It's hand-rolled because sentry-go/echo has required Echo v5
+// since v0.32, while this module is on Echo v4.
+func sentryMiddleware(repanic, waitForDelivery bool, timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			hub := sentry.CurrentHub().Clone()
+			hub.Scope().SetRequest(c.Request())
+			c.Set(sentryHubContextKey, hub)
+			c.SetRequest(c.Request().WithContext(sentry.SetHubOnContext(c.Request().Context(), hub)))
+
+			defer func() {
+				if r := recover(); r != nil {
+					hub.WithScope(func(scope *sentry.Scope) {
+						if sc := traceotel.SpanContextFromContext(c.Request().Context()); sc.IsValid() {
+							scope.SetTag("trace_id", sc.TraceID().String())
+							scope.SetTag("span_id", sc.SpanID().String())
+						}
+						eventID := hub.RecoverWithContext(c.Request().Context(), r)
+						if eventID != nil && waitForDelivery {
+							hub.Flush(timeout)
+						}
+					})
+					if repanic {
+						panic(r)
+					}
+				}
+			}()
+			return next(c)
+		}
+	}
+}
+
+// captureToSentry forwards a request-logger error entry to Sentry, tagging
+// it with the request details and the active OTel trace/span ID so the
+// error can be correlated with the OTLP log record and trace.
+func captureToSentry(c echo.Context, v middleware.RequestLoggerValues) {
+	hub := hubFromContext(c)
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetTag("http.method", v.Method)
+		scope.SetTag("http.uri", v.URI)
+		scope.SetTag("http.status_code", strconv.Itoa(v.Status))
+		if sc := traceotel.SpanContextFromContext(c.Request().Context()); sc.IsValid() {
+			scope.SetTag("trace_id", sc.TraceID().String())
+			scope.SetTag("span_id", sc.SpanID().String())
+		}
+		hub.CaptureException(v.Error)
+	})
+}
+
 func SetupEcho(ctx context.Context, logger *SlogAdapter) error {
 	e := echo.New()
 	e.HideBanner = true
 	e.Logger.SetOutput(logger)
 
+	sentryEnabled, err := setupSentry()
+	if err != nil {
+		return err
+	}
+	if sentryEnabled {
+		defer sentry.Flush(2 * time.Second)
+	}
+
 	// build ignore list from env or fall back to defaults
 	var ignore []string
 	if val, ok := os.LookupEnv("LOG_IGNORE_WEBPATHS"); !ok {
@@ -41,6 +199,13 @@ func SetupEcho(ctx context.Context, logger *SlogAdapter) error {
 	stringignore := strings.Join(ignore, ",")
 	slog.Info("web_request.log_ignore_paths", "paths", stringignore)
 
+	reqHeaderNames := parseHeaderNames(os.Getenv("HTTP_LOG_REQUEST_HEADERS"))
+	respHeaderNames := parseHeaderNames(os.Getenv("HTTP_LOG_RESPONSE_HEADERS"))
+	redactHeaders := map[string]struct{}{}
+	for _, h := range parseHeaderNames(os.Getenv("HTTP_LOG_REDACT_HEADERS")) {
+		redactHeaders[h] = struct{}{}
+	}
+
 	contains := func(list []string, s string) bool {
 		for _, v := range list {
 			if v == s {
@@ -65,32 +230,59 @@ func SetupEcho(ctx context.Context, logger *SlogAdapter) error {
 		LogUserAgent: true,
 		HandleError:  true, // forwards error to the global error handler, so it can decide appropriate status code
 		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			attrs := []slog.Attr{
+				slog.String("method", v.Method),
+				slog.Int("status", v.Status),
+				slog.String("host", v.Host),
+				slog.String("uri", v.URI),
+				slog.String("agent", v.UserAgent),
+			}
+
+			reqAttrs, reqSpanAttrs := headerAttrs("request", c.Request().Header, reqHeaderNames, redactHeaders)
+			respAttrs, respSpanAttrs := headerAttrs("response", c.Response().Header(), respHeaderNames, redactHeaders)
+			attrs = append(attrs, reqAttrs...)
+			attrs = append(attrs, respAttrs...)
+
+			if span := traceotel.SpanFromContext(c.Request().Context()); span.SpanContext().IsValid() {
+				span.SetAttributes(reqSpanAttrs...)
+				span.SetAttributes(respSpanAttrs...)
+			}
+
 			if v.Error == nil {
-				logger.LogAttrs(ctx, slog.LevelInfo, "web_request",
-					slog.String("method", v.Method),
-					slog.Int("status", v.Status),
-					slog.String("host", v.Host),
-					slog.String("uri", v.URI),
-					slog.String("agent", v.UserAgent),
-				)
+				logger.LogAttrs(ctx, slog.LevelInfo, "web_request", attrs...)
 			} else {
-				logger.LogAttrs(ctx, slog.LevelError, "web_request_error",
-					slog.String("method", v.Method),
-					slog.Int("status", v.Status),
-					slog.String("host", v.Host),
-					slog.String("uri", v.URI),
-					slog.String("agent", v.UserAgent),
-					slog.String("err", v.Error.Error()),
-				)
+				attrs = append(attrs, slog.String("err", v.Error.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "web_request_error", attrs...)
+				if sentryEnabled {
+					captureToSentry(c, v)
+				}
 			}
 			return nil
 		},
 	}))
 
 	e.Use(middleware.Recover())
+	if sentryEnabled {
+		// Echo applies middleware back-to-front, so registering
+		// sentryMiddleware after Recover() makes it the innermost frame:
+		// its own recover() runs first and captures the panic, then it
+		// repanics outward so Recover() still turns it into a 500.
+		e.Use(sentryMiddleware(
+			boolEnv("SENTRY_REPANIC", true),
+			boolEnv("SENTRY_WAIT_FOR_DELIVERY", false),
+			durationEnv("SENTRY_TIMEOUT", 2*time.Second),
+		))
+	}
 	e.Use(otelecho.Middleware("http.server/echo", otelecho.WithSkipper(func(c echo.Context) bool {
 		return c.Path() == "/auth/health" || c.Path() == "/auth/ready"
-	})))	
+	})))
+
+	if resolveExporterName("OTEL_METRICS_EXPORTER", signalEndpoint("METRICS")) == "prometheus" {
+		// otelprometheus.New() only registers a collector against the
+		// default registry; something still has to serve it, so mount it
+		// here when that reader is selected.
+		e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+	}
 
 	e.GET("/", hello)
 	e.GET("/health", health)
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	otel "go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+)
+
+// NewGRPCClientOptions returns grpc.DialOption values that instrument
+// outgoing calls with the tracer/meter providers and propagator configured
+// by SetupOtel, so downstream RPCs (including application gRPC clients, and
+// any OTLP gRPC exporter a caller dials themselves) emit rpc.client.*
+// spans and metrics. Combine with grpc.WithTransportCredentials built from
+// the TLS env vars in tlsConfigFromEnv when dialing over TLS.
+func NewGRPCClientOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(otel.GetTracerProvider()),
+			otelgrpc.WithMeterProvider(otel.GetMeterProvider()),
+			otelgrpc.WithPropagators(otel.GetTextMapPropagator()),
+		)),
+	}
+}
+
+// NewGRPCServerOptions returns grpc.ServerOption values that instrument
+// inbound calls with the same tracer/meter providers and propagator,
+// producing rpc.server.* spans and duration/size metrics.
+func NewGRPCServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithTracerProvider(otel.GetTracerProvider()),
+			otelgrpc.WithMeterProvider(otel.GetMeterProvider()),
+			otelgrpc.WithPropagators(otel.GetTextMapPropagator()),
+		)),
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	logotelglobal "go.opentelemetry.io/otel/log/global"
+)
+
+// SlogAdapter embeds slog.Logger and adds a Sync method so callers that
+// expect a zap-like logger can still call Sync().
+type SlogAdapter struct {
+	*slog.Logger
+}
+
+func (a *SlogAdapter) Sync() error { return nil }
+
+// Write implements io.Writer so a *SlogAdapter can be passed to
+// echo.Logger.SetOutput, logging each write as a single Info record with
+// any trailing newline trimmed.
+func (a *SlogAdapter) Write(p []byte) (int, error) {
+	a.Logger.Info(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+// NewSlogHandler wraps the global OTel LoggerProvider (set up by SetupOtel)
+// so slog records emitted through it become OTLP log records under the
+// given instrumentation scope name.
+func NewSlogHandler(name string) slog.Handler {
+	return otelslog.NewHandler(name, otelslog.WithLoggerProvider(logotelglobal.GetLoggerProvider()))
+}
+
+// fanoutHandler writes every record to both a human-readable handler and an
+// OTel handler, so operators still get stderr output even when the OTLP
+// logs pipeline is also configured.
+type fanoutHandler struct {
+	stderr slog.Handler
+	otel   slog.Handler
+}
+
+// NewFanoutHandler fans a record out to stderr and otel.
+func NewFanoutHandler(stderr, otel slog.Handler) slog.Handler {
+	return &fanoutHandler{stderr: stderr, otel: otel}
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.stderr.Enabled(ctx, level) || h.otel.Enabled(ctx, level)
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	if err := h.stderr.Handle(ctx, r); err != nil {
+		return err
+	}
+	// the otel bridge pulls trace_id/span_id off ctx itself, so the same
+	// context used for the stderr record carries them through here too.
+	return h.otel.Handle(ctx, r.Clone())
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewFanoutHandler(h.stderr.WithAttrs(attrs), h.otel.WithAttrs(attrs))
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	return NewFanoutHandler(h.stderr.WithGroup(name), h.otel.WithGroup(name))
+}
+
+// SetupLogger installs the default slog.Logger. When the logs signal is
+// configured (see signalEndpoint in otel.go), records fan out to both
+// stderr and the OTel LoggerProvider set up by SetupOtel; otherwise it
+// behaves like a plain stderr logger.
+func SetupLogger(ctx context.Context) (*SlogAdapter, func(context.Context) error, error) {
+	stderrHandler := slog.NewTextHandler(os.Stderr, nil)
+
+	var handler slog.Handler = stderrHandler
+	if signalEndpoint("LOGS") != "" {
+		serviceName := strings.TrimSpace(os.Getenv("OTEL_SERVICE_NAME"))
+		if serviceName == "" {
+			serviceName = "example"
+		}
+		handler = NewFanoutHandler(stderrHandler, NewSlogHandler(serviceName))
+	}
+
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+
+	shutdown := func(ctx context.Context) error { return nil }
+	return &SlogAdapter{logger}, shutdown, nil
+}
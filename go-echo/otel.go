@@ -2,17 +2,32 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"google.golang.org/grpc/credentials"
+
+	otelconfig "go.opentelemetry.io/contrib/config/v0.3.0"
+	hostmetrics "go.opentelemetry.io/contrib/instrumentation/host"
+	runtimemetrics "go.opentelemetry.io/contrib/instrumentation/runtime"
+	b3prop "go.opentelemetry.io/contrib/propagators/b3"
+	jaegerprop "go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/contrib/samplers/jaegerremote"
 	otel "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
 	logotel "go.opentelemetry.io/otel/log"
 	logotelglobal "go.opentelemetry.io/otel/log/global"
 	logotelnoop "go.opentelemetry.io/otel/log/noop"
@@ -20,22 +35,509 @@ import (
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	metricotel "go.opentelemetry.io/otel/metric"
 	metricotelnoop "go.opentelemetry.io/otel/metric/noop"
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	traceotel "go.opentelemetry.io/otel/trace"
 	traceotelnoop "go.opentelemetry.io/otel/trace/noop"
 )
 
+// signalEndpoint resolves the endpoint configured for an individual signal
+// ("TRACES", "METRICS", "LOGS"), falling back to the blanket
+// OTEL_EXPORTER_OTLP_ENDPOINT when no per-signal value is set. An empty
+// result means the signal should use a no-op provider.
+func signalEndpoint(signal string) string {
+	if v := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_ENDPOINT")); v != "" {
+		return v
+	}
+	return strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
+}
+
+// signalProtocol resolves the protocol ("grpc" or "http") configured for an
+// individual signal, falling back to the blanket OTEL_EXPORTER_OTLP_PROTOCOL,
+// and defaulting to "http" to match the OTLP exporter's own default.
+func signalProtocol(signal string) string {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_PROTOCOL")))
+	if v == "" {
+		v = strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")))
+	}
+	if v == "" {
+		v = "http"
+	}
+	return v
+}
+
+// parseHeaders parses a "key1=value1,key2=value2" list as used by the
+// OTEL_EXPORTER_OTLP_HEADERS family of env vars.
+func parseHeaders(raw string) map[string]string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	headers := map[string]string{}
+	for _, kv := range strings.Split(raw, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// signalHeaders merges OTEL_EXPORTER_OTLP_HEADERS with the per-signal
+// OTEL_EXPORTER_OTLP_<SIGNAL>_HEADERS override.
+func signalHeaders(signal string) map[string]string {
+	headers := parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	override := parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_HEADERS"))
+	if override == nil {
+		return headers
+	}
+	if headers == nil {
+		return override
+	}
+	for k, v := range override {
+		headers[k] = v
+	}
+	return headers
+}
+
+// isInsecure reports whether transport security is disabled for an
+// individual signal, checking OTEL_EXPORTER_OTLP_<SIGNAL>_INSECURE before
+// falling back to the blanket OTEL_EXPORTER_OTLP_INSECURE, matching the
+// fallback order of signalProtocol and signalHeaders.
+func isInsecure(signal string) bool {
+	if v := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_INSECURE")); v != "" {
+		return strings.EqualFold(v, "true")
+	}
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_INSECURE")), "true")
+}
+
+// tlsConfigFromEnv builds the *tls.Config used by the OTLP exporters from
+// OTEL_EXPORTER_OTLP_CERTIFICATE (server CA), and
+// OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE/OTEL_EXPORTER_OTLP_CLIENT_KEY (mTLS
+// client identity).
+func tlsConfigFromEnv() (*tls.Config, error) {
+	cfg := &tls.Config{}
+
+	if ca := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_CERTIFICATE")); ca != "" {
+		pem, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, fmt.Errorf("reading OTEL_EXPORTER_OTLP_CERTIFICATE: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("parsing OTEL_EXPORTER_OTLP_CERTIFICATE: no certificates found in %s", ca)
+		}
+		cfg.RootCAs = pool
+	}
+
+	clientCert := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"))
+	clientKey := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_CLIENT_KEY"))
+	if clientCert != "" && clientKey != "" {
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE/_CLIENT_KEY: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// propagatorsFromEnv builds the composite TextMapPropagator described by the
+// comma-separated OTEL_PROPAGATORS env var (tracecontext, baggage, b3,
+// b3multi, jaeger, none), defaulting to tracecontext+baggage when unset.
+func propagatorsFromEnv() propagation.TextMapPropagator {
+	val := strings.TrimSpace(os.Getenv("OTEL_PROPAGATORS"))
+	if val == "" {
+		val = "tracecontext,baggage"
+	}
+
+	var props []propagation.TextMapPropagator
+	for _, name := range strings.Split(val, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case "none":
+			return propagation.NewCompositeTextMapPropagator()
+		case "tracecontext":
+			props = append(props, propagation.TraceContext{})
+		case "baggage":
+			props = append(props, propagation.Baggage{})
+		case "b3":
+			props = append(props, b3prop.New(b3prop.WithInjectEncoding(b3prop.B3SingleHeader)))
+		case "b3multi":
+			props = append(props, b3prop.New(b3prop.WithInjectEncoding(b3prop.B3MultipleHeader)))
+		case "jaeger":
+			props = append(props, jaegerprop.Jaeger{})
+		default:
+			slog.Warn("ignoring unknown OTEL_PROPAGATORS entry", "name", name)
+		}
+	}
+	if len(props) == 0 {
+		return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+	return propagation.NewCompositeTextMapPropagator(props...)
+}
+
+// jaegerRemoteSampler builds a jaegerremote.New sampler from the
+// comma-separated key=value pairs in OTEL_TRACES_SAMPLER_ARG (endpoint,
+// pollingIntervalMs, initialSamplingRate), matching the arg format the
+// OTel spec defines for the jaeger_remote sampler. It polls serviceName's
+// per-operation strategies from a Jaeger remote sampling endpoint, falling
+// back to a ratio sampler until the first poll succeeds.
+func jaegerRemoteSampler(serviceName string) tracesdk.Sampler {
+	arg := parseHeaders(os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+
+	endpoint := arg["endpoint"]
+	if endpoint == "" {
+		endpoint = "http://localhost:5778/sampling"
+	}
+
+	pollingInterval := 60 * time.Second
+	if v, ok := arg["pollingIntervalMs"]; ok {
+		if ms, err := strconv.Atoi(v); err == nil {
+			pollingInterval = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	initialRate := 1.0
+	if v, ok := arg["initialSamplingRate"]; ok {
+		if r, err := strconv.ParseFloat(v, 64); err == nil {
+			initialRate = r
+		}
+	}
+
+	return tracesdk.ParentBased(jaegerremote.New(
+		serviceName,
+		jaegerremote.WithSamplingServerURL(endpoint),
+		jaegerremote.WithSamplingRefreshInterval(pollingInterval),
+		jaegerremote.WithInitialSampler(tracesdk.TraceIDRatioBased(initialRate)),
+	))
+}
+
+// samplerFromEnv builds the head-based sampler described by
+// OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG, defaulting to
+// parentbased_always_on per the OTel spec default.
+func samplerFromEnv(serviceName string) tracesdk.Sampler {
+	ratio := func() float64 {
+		arg := strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+		if arg == "" {
+			return 1.0
+		}
+		r, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return 1.0
+		}
+		return r
+	}
+
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER"))) {
+	case "always_on":
+		return tracesdk.AlwaysSample()
+	case "always_off":
+		return tracesdk.NeverSample()
+	case "traceidratio":
+		return tracesdk.TraceIDRatioBased(ratio())
+	case "parentbased_always_off":
+		return tracesdk.ParentBased(tracesdk.NeverSample())
+	case "parentbased_traceidratio":
+		return tracesdk.ParentBased(tracesdk.TraceIDRatioBased(ratio()))
+	case "jaeger_remote":
+		return jaegerRemoteSampler(serviceName)
+	case "parentbased_always_on", "":
+		return tracesdk.ParentBased(tracesdk.AlwaysSample())
+	default:
+		return tracesdk.ParentBased(tracesdk.AlwaysSample())
+	}
+}
+
+// resolveExporterName picks the exporter implementation for a signal from
+// its OTEL_<SIGNAL>_EXPORTER env var, falling back to "otlp" when an
+// endpoint is configured for backward compatibility, and "none" otherwise.
+func resolveExporterName(envVar, endpoint string) string {
+	if name := strings.ToLower(strings.TrimSpace(os.Getenv(envVar))); name != "" {
+		return name
+	}
+	if endpoint == "" {
+		return "none"
+	}
+	return "otlp"
+}
+
+// startAutoInstrumentation starts the contrib runtime/host instrumentation
+// against mp for each entry named in the comma-separated
+// OTEL_GO_AUTO_INSTRUMENT env var (runtime, host), producing baseline
+// process.runtime.go.* and system.* metrics without any per-app wiring.
+func startAutoInstrumentation(mp metricotel.MeterProvider) error {
+	val := strings.TrimSpace(os.Getenv("OTEL_GO_AUTO_INSTRUMENT"))
+	if val == "" {
+		return nil
+	}
+
+	var errs []error
+	for _, name := range strings.Split(val, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case "runtime":
+			if err := runtimemetrics.Start(runtimemetrics.WithMeterProvider(mp)); err != nil {
+				errs = append(errs, fmt.Errorf("runtime instrumentation: %w", err))
+			}
+		case "host":
+			if err := hostmetrics.Start(hostmetrics.WithMeterProvider(mp)); err != nil {
+				errs = append(errs, fmt.Errorf("host instrumentation: %w", err))
+			}
+		default:
+			slog.Warn("ignoring unknown OTEL_GO_AUTO_INSTRUMENT entry", "name", name)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newOTLPLogExporter(ctx context.Context) (logsdk.Exporter, error) {
+	headers := signalHeaders("LOGS")
+	tlsCfg, err := tlsConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("logs exporter: %w", err)
+	}
+
+	if signalProtocol("LOGS") == "grpc" {
+		opts := []otlploggrpc.Option{}
+		if headers != nil {
+			opts = append(opts, otlploggrpc.WithHeaders(headers))
+		}
+		if endpoint := signalEndpoint("LOGS"); endpoint != "" {
+			opts = append(opts, otlploggrpc.WithEndpointURL(endpoint))
+		}
+		if isInsecure("LOGS") {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+		return otlploggrpc.New(ctx, opts...)
+	}
+
+	opts := []otlploghttp.Option{}
+	if headers != nil {
+		opts = append(opts, otlploghttp.WithHeaders(headers))
+	}
+	if endpoint := signalEndpoint("LOGS"); endpoint != "" {
+		opts = append(opts, otlploghttp.WithEndpointURL(endpoint))
+	}
+	if isInsecure("LOGS") {
+		opts = append(opts, otlploghttp.WithInsecure())
+	} else {
+		opts = append(opts, otlploghttp.WithTLSClientConfig(tlsCfg))
+	}
+	return otlploghttp.New(ctx, opts...)
+}
+
+// logExporters maps an OTEL_LOGS_EXPORTER value to its constructor.
+var logExporters = map[string]func(context.Context) (logsdk.Exporter, error){
+	"otlp":   newOTLPLogExporter,
+	"stdout": func(ctx context.Context) (logsdk.Exporter, error) { return stdoutlog.New() },
+	"none":   func(ctx context.Context) (logsdk.Exporter, error) { return nil, nil },
+}
+
+func newLogExporter(ctx context.Context) (logsdk.Exporter, error) {
+	name := resolveExporterName("OTEL_LOGS_EXPORTER", signalEndpoint("LOGS"))
+	ctor, ok := logExporters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown OTEL_LOGS_EXPORTER %q", name)
+	}
+	slog.Info("Using exporter", "signal", "logs", "type", name)
+	return ctor(ctx)
+}
+
+func newOTLPMetricExporter(ctx context.Context) (metricsdk.Exporter, error) {
+	headers := signalHeaders("METRICS")
+	tlsCfg, err := tlsConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("metrics exporter: %w", err)
+	}
+
+	if signalProtocol("METRICS") == "grpc" {
+		opts := []otlpmetricgrpc.Option{}
+		if headers != nil {
+			opts = append(opts, otlpmetricgrpc.WithHeaders(headers))
+		}
+		if endpoint := signalEndpoint("METRICS"); endpoint != "" {
+			opts = append(opts, otlpmetricgrpc.WithEndpointURL(endpoint))
+		}
+		if isInsecure("METRICS") {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	}
+
+	opts := []otlpmetrichttp.Option{}
+	if headers != nil {
+		opts = append(opts, otlpmetrichttp.WithHeaders(headers))
+	}
+	if endpoint := signalEndpoint("METRICS"); endpoint != "" {
+		opts = append(opts, otlpmetrichttp.WithEndpointURL(endpoint))
+	}
+	if isInsecure("METRICS") {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	} else {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+	}
+	return otlpmetrichttp.New(ctx, opts...)
+}
+
+// metricReaders maps an OTEL_METRICS_EXPORTER value to its constructor.
+// Unlike traces/logs, "prometheus" produces a metricsdk.Reader directly
+// (a pull-based scrape endpoint) rather than a push Exporter wrapped in a
+// PeriodicReader, so this registry returns Readers for every entry.
+var metricReaders = map[string]func(context.Context) (metricsdk.Reader, error){
+	"otlp": func(ctx context.Context) (metricsdk.Reader, error) {
+		exp, err := newOTLPMetricExporter(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return metricsdk.NewPeriodicReader(exp), nil
+	},
+	"stdout": func(ctx context.Context) (metricsdk.Reader, error) {
+		exp, err := stdoutmetric.New()
+		if err != nil {
+			return nil, err
+		}
+		return metricsdk.NewPeriodicReader(exp), nil
+	},
+	"prometheus": func(ctx context.Context) (metricsdk.Reader, error) {
+		return otelprometheus.New()
+	},
+	"none": func(ctx context.Context) (metricsdk.Reader, error) { return nil, nil },
+}
+
+func newMetricReader(ctx context.Context) (metricsdk.Reader, error) {
+	name := resolveExporterName("OTEL_METRICS_EXPORTER", signalEndpoint("METRICS"))
+	ctor, ok := metricReaders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown OTEL_METRICS_EXPORTER %q", name)
+	}
+	slog.Info("Using exporter", "signal", "metrics", "type", name)
+	return ctor(ctx)
+}
+
+func newOTLPTraceExporter(ctx context.Context) (tracesdk.SpanExporter, error) {
+	headers := signalHeaders("TRACES")
+	tlsCfg, err := tlsConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("traces exporter: %w", err)
+	}
+
+	if signalProtocol("TRACES") == "grpc" {
+		opts := []otlptracegrpc.Option{}
+		if headers != nil {
+			opts = append(opts, otlptracegrpc.WithHeaders(headers))
+		}
+		if endpoint := signalEndpoint("TRACES"); endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+		}
+		if isInsecure("TRACES") {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	}
+
+	opts := []otlptracehttp.Option{}
+	if headers != nil {
+		opts = append(opts, otlptracehttp.WithHeaders(headers))
+	}
+	if endpoint := signalEndpoint("TRACES"); endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	if isInsecure("TRACES") {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+	}
+	return otlptracehttp.New(ctx, opts...)
+}
+
+// traceExporters maps an OTEL_TRACES_EXPORTER value to its constructor.
+var traceExporters = map[string]func(context.Context) (tracesdk.SpanExporter, error){
+	"otlp":   newOTLPTraceExporter,
+	"stdout": func(ctx context.Context) (tracesdk.SpanExporter, error) { return stdouttrace.New() },
+	"none":   func(ctx context.Context) (tracesdk.SpanExporter, error) { return nil, nil },
+}
+
+func newTraceExporter(ctx context.Context) (tracesdk.SpanExporter, error) {
+	name := resolveExporterName("OTEL_TRACES_EXPORTER", signalEndpoint("TRACES"))
+	ctor, ok := traceExporters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q", name)
+	}
+	slog.Info("Using exporter", "signal", "traces", "type", name)
+	return ctor(ctx)
+}
+
+// setupOtelFromConfigFile builds the logger/meter/tracer providers from the
+// declarative OpenTelemetry Configuration file at path, using
+// go.opentelemetry.io/contrib/config instead of the env-var wiring below.
+func setupOtelFromConfigFile(ctx context.Context, path string) (func(context.Context) error, error) {
+	slog.Info("Configuring OTEL from file", "path", path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading OTEL_EXPERIMENTAL_CONFIG_FILE: %w", err)
+	}
+
+	conf, err := otelconfig.ParseYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing OTEL_EXPERIMENTAL_CONFIG_FILE %s: %w", path, err)
+	}
+
+	sdk, err := otelconfig.NewSDK(
+		otelconfig.WithContext(ctx),
+		otelconfig.WithOpenTelemetryConfiguration(*conf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building SDK from OTEL_EXPERIMENTAL_CONFIG_FILE: %w", err)
+	}
+
+	logotelglobal.SetLoggerProvider(sdk.LoggerProvider())
+	otel.SetMeterProvider(sdk.MeterProvider())
+	otel.SetTracerProvider(sdk.TracerProvider())
+	// the declarative config file only covers the log/metric/trace
+	// providers, so propagation and auto-instrumentation are still wired
+	// up the same way as the env-var path below.
+	otel.SetTextMapPropagator(propagatorsFromEnv())
+	if err := startAutoInstrumentation(sdk.MeterProvider()); err != nil {
+		return nil, fmt.Errorf("starting OTEL_GO_AUTO_INSTRUMENT instrumentation: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		slog.Info("Shutting down OTEL")
+		return sdk.Shutdown(ctx)
+	}, nil
+}
+
 func SetupOtel(ctx context.Context) (func(context.Context) error, error) {
-	var le logsdk.Exporter
-	var me metricsdk.Exporter
-	var te tracesdk.SpanExporter
-	var err error
+	if path := strings.TrimSpace(os.Getenv("OTEL_EXPERIMENTAL_CONFIG_FILE")); path != "" {
+		return setupOtelFromConfigFile(ctx, path)
+	}
 
 	serviceName := strings.TrimSpace(os.Getenv("OTEL_SERVICE_NAME"))
 	if serviceName == "" {
@@ -56,25 +558,20 @@ func SetupOtel(ctx context.Context) (func(context.Context) error, error) {
 		return nil, err
 	}
 
-	endpoint := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
 	slog.Info("Configuring OTEL")
-	switch {
-	case endpoint == "":
-		slog.Info("Using OLTP exporter type", "type", "noop")
-		le = nil
-		me = nil
-		te = nil
-	case strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"))) == "grpc":
-		slog.Info("Using OLTP exporter type", "type", "grpc")
-		le, err = otlploggrpc.New(ctx)
-		me, err = otlpmetricgrpc.New(ctx)
-		te, err = otlptracegrpc.New(ctx)
-	default:
-		slog.Info("Using OLTP exporter type", "type", "http")
-		le, err = otlploghttp.New(ctx)
-		me, err = otlpmetrichttp.New(ctx)
-		te, err = otlptracehttp.New(ctx)
+
+	// each signal is configured (and may fail) independently, so a backend
+	// that only wants traces doesn't have to also stand up a metrics/logs
+	// collector, and vice versa.
+	le, err := newLogExporter(ctx)
+	if err != nil {
+		return nil, err
 	}
+	mr, err := newMetricReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	te, err := newTraceExporter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +589,9 @@ func SetupOtel(ctx context.Context) (func(context.Context) error, error) {
 		)
 	}
 
-	if me != nil {
+	if mr != nil {
 		mp = metricsdk.NewMeterProvider(
-			metricsdk.WithReader(
-				metricsdk.NewPeriodicReader(me),
-			),
+			metricsdk.WithReader(mr),
 			metricsdk.WithResource(res),
 		)
 	}
@@ -105,6 +600,7 @@ func SetupOtel(ctx context.Context) (func(context.Context) error, error) {
 		tp = tracesdk.NewTracerProvider(
 			tracesdk.WithBatcher(te),
 			tracesdk.WithResource(res),
+			tracesdk.WithSampler(samplerFromEnv(serviceName)),
 		)
 	}
 
@@ -112,6 +608,14 @@ func SetupOtel(ctx context.Context) (func(context.Context) error, error) {
 	logotelglobal.SetLoggerProvider(lp)
 	otel.SetMeterProvider(mp)
 	otel.SetTracerProvider(tp)
+	// honor incoming traceparent/baggage (or B3/Jaeger, per OTEL_PROPAGATORS)
+	// headers so otelecho continues a caller's trace instead of always
+	// starting a new one.
+	otel.SetTextMapPropagator(propagatorsFromEnv())
+
+	if err := startAutoInstrumentation(mp); err != nil {
+		return nil, fmt.Errorf("starting OTEL_GO_AUTO_INSTRUMENT instrumentation: %w", err)
+	}
 
 	// configure shutting down
 	log_shutdown := func(ctx context.Context) error {
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	otel "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type pgxTracerConfig struct {
+	redactors       []*regexp.Regexp
+	maxStatementLen int
+	skip            func(sql string) bool
+}
+
+// PgxTracerOption configures a PgxTracer.
+type PgxTracerOption func(*pgxTracerConfig)
+
+// WithStatementRedaction scrubs statement text matched by patterns (e.g.
+// literal values) before it's attached to a span as db.statement.
+func WithStatementRedaction(patterns ...*regexp.Regexp) PgxTracerOption {
+	return func(c *pgxTracerConfig) { c.redactors = append(c.redactors, patterns...) }
+}
+
+// WithMaxStatementLength truncates db.statement to n bytes, appending "...".
+// A value <= 0 disables truncation.
+func WithMaxStatementLength(n int) PgxTracerOption {
+	return func(c *pgxTracerConfig) { c.maxStatementLen = n }
+}
+
+// WithSkip omits spans for queries where skip returns true, e.g. health
+// check pings.
+func WithSkip(skip func(sql string) bool) PgxTracerOption {
+	return func(c *pgxTracerConfig) { c.skip = skip }
+}
+
+// PgxTracer is a pgx.QueryTracer that starts an OTel span per query.
+type PgxTracer struct {
+	tracer trace.Tracer
+	cfg    pgxTracerConfig
+}
+
+// NewPgxTracer builds a PgxTracer that records spans against tracerProvider.
+func NewPgxTracer(tracerProvider trace.TracerProvider, opts ...PgxTracerOption) *PgxTracer {
+	cfg := pgxTracerConfig{maxStatementLen: 2000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &PgxTracer{tracer: tracerProvider.Tracer("pgx"), cfg: cfg}
+}
+
+func (t *PgxTracer) redact(sql string) string {
+	for _, re := range t.cfg.redactors {
+		sql = re.ReplaceAllString(sql, "?")
+	}
+	if t.cfg.maxStatementLen > 0 && len(sql) > t.cfg.maxStatementLen {
+		sql = sql[:t.cfg.maxStatementLen] + "..."
+	}
+	return sql
+}
+
+func dbOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+type pgxSpanKey struct{}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if t.cfg.skip != nil && t.cfg.skip(data.SQL) {
+		return ctx
+	}
+
+	ctx, span := t.tracer.Start(ctx, "pgx.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			attribute.String("db.statement", t.redact(data.SQL)),
+			attribute.String("db.operation", dbOperation(data.SQL)),
+		),
+	)
+	return context.WithValue(ctx, pgxSpanKey{}, span)
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(pgxSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+func skipHealthChecks(sql string) bool {
+	return strings.TrimSpace(strings.ToUpper(sql)) == "SELECT 1"
+}
+
+// SetupPgxPool opens a pgxpool.Pool against dsn with a PgxTracer wired in
+// against the global TracerProvider configured by SetupOtel, skipping
+// SELECT 1 health-check pings.
+func SetupPgxPool(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ConnConfig.Tracer = NewPgxTracer(otel.GetTracerProvider(), WithSkip(skipHealthChecks))
+	return pgxpool.NewWithConfig(ctx, cfg)
+}